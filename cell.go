@@ -0,0 +1,20 @@
+package ramen
+
+import "github.com/BigJk/ramen/consolecolor"
+
+// Cell represents a single character cell of a console, holding its
+// glyph and colors.
+type Cell struct {
+	Char       rune
+	Foreground consolecolor.Color
+	Background consolecolor.Color
+
+	// Wide marks a cell that holds a double-width glyph (e.g. CJK
+	// characters or emoji), whose second half is reserved in the cell
+	// immediately to the right via Continuation.
+	Wide bool
+	// Continuation marks a cell as the reserved second half of the wide
+	// glyph in the cell to its left. Continuation cells carry no Char of
+	// their own.
+	Continuation bool
+}
@@ -0,0 +1,44 @@
+package console
+
+import "github.com/BigJk/ramen/t"
+
+// PrintANSI prints text onto the console like Print, but interprets SGR
+// escape sequences within it (\x1b[31m, 256-color \x1b[38;5;208m, truecolor
+// \x1b[38;2;R;G;Bm, reset, bold, reverse) to color each run of characters.
+// This lets output captured from tools like fzf or lipgloss be pasted into
+// a console without manually splitting it into colored runs.
+func (c *Console) PrintANSI(x, y int, text string) {
+	if y >= c.Height {
+		return
+	}
+
+	state := newSGRState()
+	cx := x
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == 0x1b && i+1 < len(runes) && runes[i+1] == '[' {
+			end := i + 2
+			for end < len(runes) && runes[end] != 'm' {
+				end++
+			}
+			if end >= len(runes) {
+				// Unterminated escape sequence trailing off the end of
+				// text; nothing left to parse, matching FromANSI.
+				return
+			}
+
+			state.Apply(t.ParseCSIParams(string(runes[i+2 : end])))
+			i = end
+			continue
+		}
+
+		if cx >= c.Width {
+			return
+		}
+
+		fg, bg := state.Resolve()
+		c.Transform(cx, y, t.Char(runes[i]), t.Foreground(fg), t.Background(bg))
+		cx++
+	}
+}
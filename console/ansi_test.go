@@ -0,0 +1,41 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/BigJk/ramen/consolecolor"
+)
+
+func TestPrintANSIAppliesSGRColor(t *testing.T) {
+	con, err := NewHeadless(10, 1, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	con.PrintANSI(0, 0, "\x1b[31mhi")
+
+	snap := con.Snapshot()
+	if snap[0][0].Char != 'h' || snap[0][0].Foreground != consolecolor.New(205, 0, 0) {
+		t.Fatalf("expected 'h' colored ANSI red, got %+v", snap[0][0])
+	}
+	if snap[1][0].Char != 'i' {
+		t.Fatalf("expected 'i' in the following cell, got %q", snap[1][0].Char)
+	}
+}
+
+func TestPrintANSIIgnoresUnterminatedEscape(t *testing.T) {
+	con, err := NewHeadless(10, 1, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	con.PrintANSI(0, 0, "ab\x1b[31")
+
+	snap := con.Snapshot()
+	if snap[0][0].Char != 'a' || snap[1][0].Char != 'b' {
+		t.Fatalf("expected 'a','b' printed before the truncated escape, got %+v %+v", snap[0][0], snap[1][0])
+	}
+	if snap[2][0].Char != 0 {
+		t.Fatalf("expected the truncated escape to be dropped rather than printed as a stray glyph, got %q", snap[2][0].Char)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"sort"
 
+	"image"
 	"image/color"
 
 	"github.com/BigJk/ramen"
@@ -16,6 +17,7 @@ import (
 	"github.com/BigJk/ramen/t"
 	"github.com/hajimehoshi/ebiten"
 	"github.com/hajimehoshi/ebiten/ebitenutil"
+	"github.com/mattn/go-runewidth"
 )
 
 var emptyCell = ramen.Cell{
@@ -38,7 +40,7 @@ type Console struct {
 	isSubConsole bool
 
 	mtx       *sync.RWMutex
-	updates   []int
+	dirty     map[int]struct{}
 	buffer    [][]ramen.Cell
 	lastFrame int64
 
@@ -46,10 +48,22 @@ type Console struct {
 
 	preRenderHook  func(screen *ebiten.Image, timeElapsed float64) error
 	postRenderHook func(screen *ebiten.Image, timeElapsed float64) error
+
+	handler    Interactive
+	focusStack []*Console
+
+	hoverValid bool
+	hoverX     int
+	hoverY     int
+
+	grid *Grid
+
+	headless bool
 }
 
-// New creates a new console
-func New(width, height int, font *font.Font, title string) (*Console, error) {
+// newBuffer allocates a width x height cell buffer initialized to the
+// default empty cell look.
+func newBuffer(width, height int) [][]ramen.Cell {
 	buf := make([][]ramen.Cell, width)
 	for x := range buf {
 		buf[x] = make([]ramen.Cell, height)
@@ -57,7 +71,12 @@ func New(width, height int, font *font.Font, title string) (*Console, error) {
 			buf[x][y] = emptyCell
 		}
 	}
+	return buf
+}
 
+// newLines allocates one ebiten.Image per column, each tall enough to hold
+// a full column of cells at the given font's tile size.
+func newLines(width, height int, font *font.Font) ([]*ebiten.Image, error) {
 	lines := make([]*ebiten.Image, width)
 	for i := range lines {
 		line, err := ebiten.NewImage(font.TileWidth, height*font.TileHeight, ebiten.FilterNearest)
@@ -66,6 +85,15 @@ func New(width, height int, font *font.Font, title string) (*Console, error) {
 		}
 		lines[i] = line
 	}
+	return lines, nil
+}
+
+// New creates a new console
+func New(width, height int, font *font.Font, title string) (*Console, error) {
+	lines, err := newLines(width, height, font)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Console{
 		Title:       title,
@@ -74,8 +102,8 @@ func New(width, height int, font *font.Font, title string) (*Console, error) {
 		Font:        font,
 		SubConsoles: make([]*Console, 0),
 		mtx:         new(sync.RWMutex),
-		updates:     make([]int, 0),
-		buffer:      buf,
+		dirty:       make(map[int]struct{}),
+		buffer:      newBuffer(width, height),
 		lines:       lines,
 	}, nil
 }
@@ -117,7 +145,13 @@ func (c *Console) CreateSubConsole(x, y, width, height int) (*Console, error) {
 
 	c.mtx.Lock()
 
-	sub, err := New(width, height, c.Font, "")
+	var sub *Console
+	var err error
+	if c.headless {
+		sub, err = NewHeadless(width, height, c.Font, "")
+	} else {
+		sub, err = New(width, height, c.Font, "")
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +170,14 @@ func (c *Console) CreateSubConsole(x, y, width, height int) (*Console, error) {
 	return sub, nil
 }
 
+// SetInteractive registers i as the input handler for this console. Once
+// set, pushing the console onto a focus stack will route Events to i.
+// This is mainly used by wrapper types, such as TerminalConsole, that
+// embed a Console but need to receive the routed input themselves.
+func (c *Console) SetInteractive(i Interactive) {
+	c.handler = i
+}
+
 // RemoveSubConsole removes a sub-console from his parent
 func (c *Console) RemoveSubConsole(con *Console) error {
 	c.mtx.Lock()
@@ -175,28 +217,33 @@ func (c *Console) Clear(x, y, width, height int, transformer ...t.Transformer) e
 	c.mtx.Lock()
 
 	for px := 0; px < width; px++ {
-		mustUpdate := false
 		for py := 0; py < height; py++ {
+			cx, cy := px+x, py+y
+
 			if len(transformer) == 0 {
-				if c.buffer[px+x][py+y] != emptyCell {
-					c.buffer[px+x][py+y] = emptyCell
-					mustUpdate = true
-				} else {
-					for i := range transformer {
-						changed, err := transformer[i].Transform(&c.buffer[x][y])
-						if err != nil {
-							return err
-						}
-						if changed {
-							mustUpdate = true
-						}
-					}
+				if c.buffer[cx][cy] != emptyCell {
+					c.invalidateWidePairLocked(cx, cy)
+					c.buffer[cx][cy] = emptyCell
+					c.queueUpdate(cx, cy)
 				}
+				continue
 			}
-		}
 
-		if mustUpdate {
-			c.updates = append(c.updates, px+x)
+			changed := false
+			for i := range transformer {
+				cellChanged, err := transformer[i].Transform(&c.buffer[cx][cy])
+				if err != nil {
+					c.mtx.Unlock()
+					return err
+				}
+				if cellChanged {
+					changed = true
+				}
+			}
+			if changed {
+				c.invalidateWidePairLocked(cx, cy)
+				c.queueUpdate(cx, cy)
+			}
 		}
 	}
 
@@ -226,7 +273,8 @@ func (c *Console) Transform(x, y int, transformer ...t.Transformer) error {
 	}
 
 	if mustUpdate {
-		c.queueUpdate(x)
+		c.queueUpdate(x, y)
+		c.invalidateWidePairLocked(x, y)
 	}
 
 	c.mtx.Unlock()
@@ -235,17 +283,33 @@ func (c *Console) Transform(x, y int, transformer ...t.Transformer) error {
 }
 
 // Print prints a text onto the console. To give the text a different foreground or
-// background color use transformer.
+// background color use transformer. text is iterated rune by rune so multi-byte
+// UTF-8 is handled correctly, and East-Asian wide characters reserve two cells.
 func (c *Console) Print(x, y int, text string, transformer ...t.Transformer) {
 	if y >= c.Height {
 		return
 	}
 
-	for i := range text {
-		if x+i >= c.Width {
+	cx := x
+	for _, r := range text {
+		if cx >= c.Width {
 			return
 		}
-		c.Transform(x+i, y, append(transformer, t.CharByte(text[i]))...)
+
+		// A wide glyph only gets to keep its Wide flag if there's a cell to
+		// its right to reserve as Continuation; otherwise it's treated as
+		// narrow so no cell is ever left Wide without a paired partner.
+		wide := runewidth.RuneWidth(r) >= 2 && cx+1 < c.Width
+
+		c.Transform(cx, y, append(transformer, t.Char(r))...)
+		c.setWide(cx, y, wide)
+
+		if wide {
+			c.setContinuation(cx+1, y, true)
+			cx += 2
+		} else {
+			cx++
+		}
 	}
 }
 
@@ -257,13 +321,118 @@ func (c *Console) sortSubConsoles() {
 	c.mtx.Unlock()
 }
 
-func (c *Console) queueUpdate(x int) {
-	for i := range c.updates {
-		if c.updates[i] == x {
-			return
+// queueUpdate marks the cell at x, y as damaged so the next updateCells
+// call re-blits it. Marking is idempotent; repeated damage to the same
+// cell within a frame costs nothing extra.
+func (c *Console) queueUpdate(x, y int) {
+	c.dirty[y*c.Width+x] = struct{}{}
+}
+
+// setWide marks the cell at x, y as holding (or no longer holding) a
+// double-width glyph, invalidating its continuation cell's stale half if
+// the glyph is being cleared. Acquires the console lock itself.
+func (c *Console) setWide(x, y int, wide bool) {
+	c.mtx.Lock()
+	c.setWideLocked(x, y, wide)
+	c.mtx.Unlock()
+}
+
+// setContinuation marks the cell at x, y as the reserved second half of
+// the wide glyph to its left, clearing any Char it held. Acquires the
+// console lock itself.
+func (c *Console) setContinuation(x, y int, continuation bool) {
+	c.mtx.Lock()
+	c.setContinuationLocked(x, y, continuation)
+	c.mtx.Unlock()
+}
+
+// setWideLocked is setWide's implementation for callers that already hold
+// c.mtx.
+func (c *Console) setWideLocked(x, y int, wide bool) {
+	wasWide := c.buffer[x][y].Wide
+	c.buffer[x][y].Wide = wide
+	c.queueUpdate(x, y)
+
+	if wasWide && !wide && x+1 < c.Width {
+		c.setContinuationLocked(x+1, y, false)
+	}
+}
+
+// setContinuationLocked is setContinuation's implementation for callers
+// that already hold c.mtx.
+func (c *Console) setContinuationLocked(x, y int, continuation bool) {
+	c.buffer[x][y].Continuation = continuation
+	if continuation {
+		c.buffer[x][y].Char = 0
+	}
+	c.queueUpdate(x, y)
+}
+
+// invalidateWidePairLocked clears the other half of a wide glyph pair
+// that would otherwise be left dangling when one half of it is
+// overwritten by Transform or Clear. The caller must already hold c.mtx.
+func (c *Console) invalidateWidePairLocked(x, y int) {
+	cell := c.buffer[x][y]
+
+	if cell.Wide {
+		// setWideLocked(x, y, false) also clears the stale Wide flag left
+		// on this cell itself, not just its continuation partner.
+		c.setWideLocked(x, y, false)
+	}
+	if cell.Continuation && x > 0 {
+		c.setWideLocked(x-1, y, false)
+	}
+}
+
+// Resize changes the width and height of the console, reallocating its
+// buffer and line images. Existing cell contents are discarded since the
+// buffer is allocated fresh, matching the empty-cell state produced by New.
+func (c *Console) Resize(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("width and height must be greater than zero")
+	}
+
+	buf := newBuffer(width, height)
+
+	var lines []*ebiten.Image
+	if !c.headless {
+		var err error
+		lines, err = newLines(width, height, c.Font)
+		if err != nil {
+			return err
 		}
 	}
-	c.updates = append(c.updates, x)
+
+	c.mtx.Lock()
+	c.Width = width
+	c.Height = height
+	c.buffer = buf
+	c.lines = lines
+	c.dirty = make(map[int]struct{})
+	grid := c.grid
+	c.mtx.Unlock()
+
+	if grid != nil {
+		return grid.Layout()
+	}
+
+	return nil
+}
+
+// scrollUp moves every row between top and bottom (inclusive) up by one
+// line, discarding the top row and clearing the newly exposed bottom row.
+// It's used by the terminal VT100 emulator to implement line-feed
+// scrolling within a DECSTBM scroll region.
+func (c *Console) scrollUp(top, bottom int) {
+	c.mtx.Lock()
+	for x := range c.buffer {
+		copy(c.buffer[x][top:bottom], c.buffer[x][top+1:bottom+1])
+		c.buffer[x][bottom] = emptyCell
+		for y := top; y <= bottom; y++ {
+			c.queueUpdate(x, y)
+		}
+	}
+	c.mtx.Unlock()
 }
 
 func (c *Console) checkOutOfBounds(x, y int) error {
@@ -273,44 +442,115 @@ func (c *Console) checkOutOfBounds(x, y int) error {
 	return nil
 }
 
-func (c *Console) updateLine(x int) {
-	c.lines[x].Fill(color.NRGBA{0, 0, 0, 0})
-	for y := range c.buffer[x] {
-		if c.buffer[x][y].Background.A > 0 {
-			ebitenutil.DrawRect(c.lines[x], 0, float64(y*c.Font.TileHeight), float64(c.Font.TileWidth), float64(c.Font.TileHeight), c.buffer[x][y].Background)
-		}
+// updateCell re-blits a single cell of column x into c.lines[x]. The
+// caller is responsible for clearing the cell's area first.
+func (c *Console) updateCell(x, y int) {
+	if c.buffer[x][y].Continuation {
+		c.updateContinuationCell(x, y)
+		return
+	}
 
-		if c.buffer[x][y].Char == 0 {
-			continue
-		}
+	if c.buffer[x][y].Background.A > 0 {
+		ebitenutil.DrawRect(c.lines[x], 0, float64(y*c.Font.TileHeight), float64(c.Font.TileWidth), float64(c.Font.TileHeight), c.buffer[x][y].Background)
+	}
 
-		op := c.Font.ToOptions(c.buffer[x][y].Char)
-		op.GeoM.Translate(0, float64(y*c.Font.TileHeight))
+	if c.buffer[x][y].Char == 0 {
+		return
+	}
 
-		if !c.Font.IsTile(c.buffer[x][y].Char) {
-			op.ColorM.Scale(c.buffer[x][y].Foreground.Floats())
-		}
+	op := c.Font.ToOptions(c.buffer[x][y].Char)
+	op.GeoM.Translate(0, float64(y*c.Font.TileHeight))
 
-		c.lines[x].DrawImage(c.Font.Image, op)
+	if !c.Font.IsTile(c.buffer[x][y].Char) {
+		op.ColorM.Scale(c.buffer[x][y].Foreground.Floats())
 	}
+
+	c.lines[x].DrawImage(c.Font.Image, op)
 }
 
-func (c *Console) flushUpdates() {
-	for i := range c.updates {
-		c.updateLine(c.updates[i])
+// updateContinuationCell draws the right half of the wide glyph held by
+// the preceding cell into x's column strip. Continuation cells carry no
+// Char of their own, so the glyph is fetched from buffer[x-1][y] and its
+// source rect shifted left by one tile width.
+func (c *Console) updateContinuationCell(x, y int) {
+	if x == 0 {
+		return
 	}
 
-	if len(c.updates) > 0 {
-		c.updates = make([]int, 0)
+	lead := c.buffer[x-1][y]
+
+	if lead.Background.A > 0 {
+		ebitenutil.DrawRect(c.lines[x], 0, float64(y*c.Font.TileHeight), float64(c.Font.TileWidth), float64(c.Font.TileHeight), lead.Background)
 	}
+
+	if !lead.Wide || lead.Char == 0 {
+		return
+	}
+
+	op := c.Font.ToOptions(lead.Char)
+	op.GeoM.Translate(-float64(c.Font.TileWidth), float64(y*c.Font.TileHeight))
+
+	if !c.Font.IsTile(lead.Char) {
+		op.ColorM.Scale(lead.Foreground.Floats())
+	}
+
+	c.lines[x].DrawImage(c.Font.Image, op)
+}
+
+// updateRun clears and redraws the vertical span of cells [yStart, yEnd]
+// in column x. Clearing is scoped to that span via SubImage instead of
+// wiping and redrawing the whole column.
+func (c *Console) updateRun(x, yStart, yEnd int) {
+	rect := image.Rect(0, yStart*c.Font.TileHeight, c.Font.TileWidth, (yEnd+1)*c.Font.TileHeight)
+	c.lines[x].SubImage(rect).(*ebiten.Image).Fill(color.NRGBA{0, 0, 0, 0})
+
+	for y := yStart; y <= yEnd; y++ {
+		c.updateCell(x, y)
+	}
+}
+
+// updateCells re-blits only the cells marked dirty since the last frame,
+// grouping each column's damage into contiguous vertical runs so a single
+// character change costs one small Fill and DrawImage pair instead of
+// redrawing the whole column.
+func (c *Console) updateCells() {
+	if len(c.dirty) == 0 {
+		return
+	}
+
+	// A headless console has no line images to blit into; the buffer
+	// itself (read via Snapshot/RenderToImage) is already up to date.
+	if !c.headless {
+		byColumn := make(map[int][]int)
+		for key := range c.dirty {
+			x, y := key%c.Width, key/c.Width
+			byColumn[x] = append(byColumn[x], y)
+		}
+
+		for x, ys := range byColumn {
+			sort.Ints(ys)
+
+			runStart := 0
+			for i := 1; i <= len(ys); i++ {
+				if i == len(ys) || ys[i] != ys[i-1]+1 {
+					c.updateRun(x, ys[runStart], ys[i-1])
+					runStart = i
+				}
+			}
+		}
+	}
+
+	c.dirty = make(map[int]struct{})
 }
 
 func (c *Console) draw(screen *ebiten.Image, offsetX, offsetY int) {
-	c.flushUpdates()
-	for x := range c.buffer {
-		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(float64((x+c.x+offsetX)*c.Font.TileWidth), float64((c.y+offsetY)*c.Font.TileHeight))
-		screen.DrawImage(c.lines[x], op)
+	c.updateCells()
+	if !c.headless {
+		for x := range c.buffer {
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(float64((x+c.x+offsetX)*c.Font.TileWidth), float64((c.y+offsetY)*c.Font.TileHeight))
+			screen.DrawImage(c.lines[x], op)
+		}
 	}
 
 	for i := range c.SubConsoles {
@@ -329,6 +569,8 @@ func (c *Console) update(screen *ebiten.Image) error {
 
 	timeElapsed := float64((time.Now().UnixNano()-c.lastFrame)/(int64(time.Millisecond)/int64(time.Nanosecond))) / 1000.0
 
+	c.pollInput()
+
 	if c.preRenderHook != nil {
 		if err := c.preRenderHook(screen, timeElapsed); err != nil {
 			return err
@@ -0,0 +1,51 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/BigJk/ramen/t"
+)
+
+// BenchmarkUpdateCellsSingleCellChange measures per-frame cost on a
+// 120x60 console when only a single cell changes each frame. Before the
+// damage-rectangle rework, every queued update re-blitted a whole column
+// (Height DrawImage calls plus a full lines[x].Fill); now only the
+// touched cell's run is cleared and redrawn. The pre-rework
+// updateLine/flushUpdates code no longer exists in the tree to benchmark
+// directly, so BenchmarkUpdateCellsFullColumnChange below stands in as
+// the worst case this rework is meant to avoid paying on every frame: it
+// dirties a whole column, which is the one case where per-cell damage
+// tracking devolves to the old column-at-a-time cost.
+func BenchmarkUpdateCellsSingleCellChange(b *testing.B) {
+	con, err := New(120, 60, newTestFont(b), "bench")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, y := i%con.Width, (i/con.Width)%con.Height
+		_ = con.Transform(x, y, t.CharByte(byte('a'+i%26)))
+		con.updateCells()
+	}
+}
+
+// BenchmarkUpdateCellsFullColumnChange measures the worst case for the
+// damage-rectangle approach: every cell in one column changes each frame,
+// so the single contiguous run spans the whole column just like the old
+// always-redraw-the-column behavior did.
+func BenchmarkUpdateCellsFullColumnChange(b *testing.B) {
+	con, err := New(120, 60, newTestFont(b), "bench")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x := i % con.Width
+		for y := 0; y < con.Height; y++ {
+			_ = con.Transform(x, y, t.CharByte(byte('a'+y%26)))
+		}
+		con.updateCells()
+	}
+}
@@ -0,0 +1,70 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/BigJk/ramen/consolecolor"
+	ramenT "github.com/BigJk/ramen/t"
+)
+
+func TestTransformQueuesOnlyTheTouchedCell(t *testing.T) {
+	con, err := NewHeadless(5, 5, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := con.Transform(2, 3, ramenT.Char('x')); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(con.dirty) != 1 {
+		t.Fatalf("expected exactly one dirty cell, got %d", len(con.dirty))
+	}
+	if _, ok := con.dirty[3*con.Width+2]; !ok {
+		t.Fatalf("expected cell (2,3) to be marked dirty")
+	}
+}
+
+func TestUpdateCellsClearsDirtySet(t *testing.T) {
+	con, err := New(5, 5, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := con.Transform(0, 0, ramenT.Char('a')); err != nil {
+		t.Fatal(err)
+	}
+	if len(con.dirty) == 0 {
+		t.Fatal("expected Transform to mark a dirty cell")
+	}
+
+	con.updateCells()
+
+	if len(con.dirty) != 0 {
+		t.Fatalf("expected updateCells to clear the dirty set, got %d entries", len(con.dirty))
+	}
+}
+
+func TestClearWithTransformerAppliesItInsteadOfResetting(t *testing.T) {
+	con, err := NewHeadless(3, 1, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	con.Print(0, 0, "abc")
+
+	bg := consolecolor.New(0, 0, 205)
+	if err := con.Clear(0, 0, 3, 1, ramenT.Background(bg)); err != nil {
+		t.Fatal(err)
+	}
+
+	snap := con.Snapshot()
+	for i := 0; i < 3; i++ {
+		if snap[i][0].Background != bg {
+			t.Fatalf("expected Clear with a transformer to apply it rather than reset the cell, got %+v", snap[i][0])
+		}
+		if snap[i][0].Char == 0 {
+			t.Fatalf("expected Clear with a transformer to leave the existing char alone, got %+v", snap[i][0])
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package console
+
+import "github.com/hajimehoshi/ebiten"
+
+// EventType identifies what kind of input an Event carries.
+type EventType int
+
+const (
+	// EventKey fires once per frame a key is held down.
+	EventKey EventType = iota
+	// EventChar fires for printable characters typed during the frame.
+	EventChar
+	// EventMouseMove fires when the cell the cursor is hovering changes.
+	EventMouseMove
+	// EventMouseDown fires on the frame a mouse button starts being held.
+	EventMouseDown
+	// EventMouseUp fires on the frame a mouse button is released.
+	EventMouseUp
+)
+
+// Modifier is a bitmask of keyboard modifier keys held while an Event was
+// generated.
+type Modifier int
+
+const (
+	ModShift Modifier = 1 << iota
+	ModAlt
+	ModCtrl
+)
+
+// Event is an input event routed to the currently focused sub-console.
+// Mouse coordinates are already translated into the receiving console's
+// own cell coordinate space.
+type Event struct {
+	Type   EventType
+	Key    ebiten.Key
+	Mod    Modifier
+	Char   rune
+	X, Y   int
+	Button ebiten.MouseButton
+}
+
+// Interactive can be implemented by anything that wants to sit in a
+// Console's focus stack and receive routed input events, e.g. a
+// TerminalConsole or a custom dialog/text-input sub-console.
+type Interactive interface {
+	// Focus is called whenever the implementor gains or loses focus.
+	Focus(focused bool)
+	// HandleEvent is called for every Event while focused. The return
+	// value reports whether the event was consumed; events are only
+	// delivered to the top of the focus stack, so an unconsumed event is
+	// not passed on to any other handler.
+	HandleEvent(e Event) bool
+}
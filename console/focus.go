@@ -0,0 +1,224 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/inpututil"
+)
+
+// watchedKeys lists the keys polled for EventKey dispatch. Printable
+// characters are delivered separately via EventChar using ebiten's own
+// IME-aware InputChars, so this only needs to cover control/navigation
+// keys that HandleEvent implementations care about.
+var watchedKeys = []ebiten.Key{
+	ebiten.KeyUp, ebiten.KeyDown, ebiten.KeyLeft, ebiten.KeyRight,
+	ebiten.KeyEnter, ebiten.KeyBackspace, ebiten.KeyTab, ebiten.KeyEscape,
+	ebiten.KeyHome, ebiten.KeyEnd,
+}
+
+// ctrlLetterKeys lists the letter keys polled solely to detect Ctrl+<letter>
+// control codes (e.g. Ctrl+C, Ctrl+D). ebiten.InputChars never reports
+// these since a real terminal doesn't "type" a character for them.
+var ctrlLetterKeys = []ebiten.Key{
+	ebiten.KeyA, ebiten.KeyB, ebiten.KeyC, ebiten.KeyD, ebiten.KeyE,
+	ebiten.KeyF, ebiten.KeyG, ebiten.KeyH, ebiten.KeyI, ebiten.KeyJ,
+	ebiten.KeyK, ebiten.KeyL, ebiten.KeyM, ebiten.KeyN, ebiten.KeyO,
+	ebiten.KeyP, ebiten.KeyQ, ebiten.KeyR, ebiten.KeyS, ebiten.KeyT,
+	ebiten.KeyU, ebiten.KeyV, ebiten.KeyW, ebiten.KeyX, ebiten.KeyY,
+	ebiten.KeyZ,
+}
+
+// currentModifiers reads the held state of the modifier keys for the
+// current frame.
+func currentModifiers() Modifier {
+	var mod Modifier
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		mod |= ModShift
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyAlt) {
+		mod |= ModAlt
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyControl) {
+		mod |= ModCtrl
+	}
+	return mod
+}
+
+// pollInput reads ebiten's input state for the current frame and routes
+// it to the focused console, performing click-to-focus hit-testing along
+// the way. It's a no-op for sub-consoles; only the root console polls.
+func (c *Console) pollInput() {
+	if c.isSubConsole {
+		return
+	}
+
+	mod := currentModifiers()
+
+	// toFocused translates root cell coordinates into the local space of
+	// the console on top of the focus stack, which receives the event.
+	toFocused := func(x, y int) (int, int) {
+		for f := c.Focused(); f != nil && f != c; f = f.parent {
+			x -= f.x
+			y -= f.y
+		}
+		return x, y
+	}
+
+	if x, y := ebiten.CursorPosition(); x >= 0 && y >= 0 {
+		cellX, cellY := x/c.Font.TileWidth, y/c.Font.TileHeight
+
+		if !c.hoverValid || cellX != c.hoverX || cellY != c.hoverY {
+			c.hoverValid = true
+			c.hoverX = cellX
+			c.hoverY = cellY
+
+			localX, localY := toFocused(cellX, cellY)
+			c.dispatch(Event{Type: EventMouseMove, X: localX, Y: localY, Mod: mod})
+		}
+
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			target, _, _ := c.hitTest(cellX, cellY)
+			if target != c.Focused() {
+				_ = c.PushFocus(target)
+			}
+			localX, localY := toFocused(cellX, cellY)
+			c.dispatch(Event{Type: EventMouseDown, X: localX, Y: localY, Button: ebiten.MouseButtonLeft, Mod: mod})
+		}
+		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+			localX, localY := toFocused(cellX, cellY)
+			c.dispatch(Event{Type: EventMouseUp, X: localX, Y: localY, Button: ebiten.MouseButtonLeft, Mod: mod})
+		}
+	}
+
+	for _, key := range watchedKeys {
+		if ebiten.IsKeyPressed(key) {
+			c.dispatch(Event{Type: EventKey, Key: key, Mod: mod})
+		}
+	}
+
+	if mod&ModCtrl != 0 {
+		for _, key := range ctrlLetterKeys {
+			if ebiten.IsKeyPressed(key) {
+				c.dispatch(Event{Type: EventKey, Key: key, Mod: mod})
+			}
+		}
+	}
+
+	for _, r := range ebiten.InputChars() {
+		c.dispatch(Event{Type: EventChar, Char: r, Mod: mod})
+	}
+}
+
+// dispatch sends e to the currently focused console's handler, if any.
+func (c *Console) dispatch(e Event) {
+	focused := c.Focused()
+	if focused == nil || focused.handler == nil {
+		return
+	}
+	focused.handler.HandleEvent(e)
+}
+
+// hitTest walks down the sub-console tree to find the topmost console
+// containing the given root-relative cell coordinates, translating the
+// coordinates into that console's own local space along the way.
+func (c *Console) hitTest(cellX, cellY int) (*Console, int, int) {
+	cur := c
+	x, y := cellX, cellY
+
+	for {
+		child := cur.directSubConsoleAt(x, y)
+		if child == nil {
+			return cur, x, y
+		}
+		x -= child.x
+		y -= child.y
+		cur = child
+	}
+}
+
+// directSubConsoleAt returns the highest-priority immediate child of c
+// whose bounds contain the given c-relative cell coordinates, or nil if
+// none match.
+func (c *Console) directSubConsoleAt(cellX, cellY int) *Console {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	for _, sub := range c.SubConsoles {
+		if cellX < sub.x || cellY < sub.y || cellX >= sub.x+sub.Width || cellY >= sub.y+sub.Height {
+			continue
+		}
+		return sub
+	}
+
+	return nil
+}
+
+// PushFocus pushes con onto the root console's focus stack, making it the
+// target of all subsequent routed input events. The previously focused
+// console (if any) is notified via Interactive.Focus(false).
+func (c *Console) PushFocus(con *Console) error {
+	if c.isSubConsole {
+		return fmt.Errorf("focus stack only lives on the root console")
+	}
+
+	c.mtx.Lock()
+	if len(c.focusStack) > 0 {
+		if h := c.focusStack[len(c.focusStack)-1].handler; h != nil {
+			h.Focus(false)
+		}
+	}
+	c.focusStack = append(c.focusStack, con)
+	c.mtx.Unlock()
+
+	if con.handler != nil {
+		con.handler.Focus(true)
+	}
+
+	return nil
+}
+
+// PopFocus removes the topmost console from the focus stack and returns
+// it, restoring focus to whatever was focused before it.
+func (c *Console) PopFocus() *Console {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if len(c.focusStack) == 0 {
+		return nil
+	}
+
+	top := c.focusStack[len(c.focusStack)-1]
+	c.focusStack = c.focusStack[:len(c.focusStack)-1]
+
+	if top.handler != nil {
+		top.handler.Focus(false)
+	}
+	if len(c.focusStack) > 0 {
+		if h := c.focusStack[len(c.focusStack)-1].handler; h != nil {
+			h.Focus(true)
+		}
+	}
+
+	return top
+}
+
+// Focused returns the console currently on top of the focus stack, or nil
+// if nothing is focused.
+func (c *Console) Focused() *Console {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	if len(c.focusStack) == 0 {
+		return nil
+	}
+	return c.focusStack[len(c.focusStack)-1]
+}
+
+// SubConsoleAt returns the topmost (highest priority) sub-console whose
+// bounds contain the given cell coordinates, or c itself if no sub-console
+// matches. Coordinates are relative to c.
+func (c *Console) SubConsoleAt(cellX, cellY int) *Console {
+	con, _, _ := c.hitTest(cellX, cellY)
+	return con
+}
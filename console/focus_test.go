@@ -0,0 +1,157 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten"
+)
+
+type stubInteractive struct {
+	focusCalls []bool
+	events     []Event
+}
+
+func (s *stubInteractive) Focus(focused bool) {
+	s.focusCalls = append(s.focusCalls, focused)
+}
+
+func (s *stubInteractive) HandleEvent(e Event) bool {
+	s.events = append(s.events, e)
+	return true
+}
+
+func TestPushPopFocus(t *testing.T) {
+	root, err := NewHeadless(10, 10, newTestFont(t), "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub1, err := root.CreateSubConsole(0, 0, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub2, err := root.CreateSubConsole(5, 5, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := root.PushFocus(sub1); err != nil {
+		t.Fatal(err)
+	}
+	if root.Focused() != sub1 {
+		t.Fatalf("expected sub1 to be focused")
+	}
+
+	if err := root.PushFocus(sub2); err != nil {
+		t.Fatal(err)
+	}
+	if root.Focused() != sub2 {
+		t.Fatalf("expected sub2 to be focused")
+	}
+
+	if popped := root.PopFocus(); popped != sub2 {
+		t.Fatalf("expected PopFocus to return sub2")
+	}
+	if root.Focused() != sub1 {
+		t.Fatalf("expected sub1 to be focused again after pop")
+	}
+
+	if popped := root.PopFocus(); popped != sub1 {
+		t.Fatalf("expected PopFocus to return sub1")
+	}
+	if root.Focused() != nil {
+		t.Fatalf("expected nothing focused after popping everything")
+	}
+}
+
+func TestPushFocusNotifiesHandlers(t *testing.T) {
+	root, err := NewHeadless(10, 10, newTestFont(t), "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub1, err := root.CreateSubConsole(0, 0, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub2, err := root.CreateSubConsole(5, 5, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h1, h2 := &stubInteractive{}, &stubInteractive{}
+	sub1.SetInteractive(h1)
+	sub2.SetInteractive(h2)
+
+	if err := root.PushFocus(sub1); err != nil {
+		t.Fatal(err)
+	}
+	if len(h1.focusCalls) != 1 || !h1.focusCalls[0] {
+		t.Fatalf("expected sub1 to receive Focus(true), got %v", h1.focusCalls)
+	}
+
+	if err := root.PushFocus(sub2); err != nil {
+		t.Fatal(err)
+	}
+	if len(h1.focusCalls) != 2 || h1.focusCalls[1] {
+		t.Fatalf("expected sub1 to receive Focus(false) when sub2 took over, got %v", h1.focusCalls)
+	}
+	if len(h2.focusCalls) != 1 || !h2.focusCalls[0] {
+		t.Fatalf("expected sub2 to receive Focus(true), got %v", h2.focusCalls)
+	}
+
+	root.PopFocus()
+	if len(h2.focusCalls) != 2 || h2.focusCalls[1] {
+		t.Fatalf("expected sub2 to receive Focus(false) on pop, got %v", h2.focusCalls)
+	}
+	if len(h1.focusCalls) != 3 || !h1.focusCalls[2] {
+		t.Fatalf("expected sub1 to receive Focus(true) again once pop restored it, got %v", h1.focusCalls)
+	}
+}
+
+func TestHitTestTranslatesCoordinates(t *testing.T) {
+	root, err := NewHeadless(10, 10, newTestFont(t), "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := root.CreateSubConsole(2, 3, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target, localX, localY := root.hitTest(4, 5)
+	if target != sub {
+		t.Fatalf("expected hitTest to find the sub-console")
+	}
+	if localX != 2 || localY != 2 {
+		t.Fatalf("expected local coords (2,2), got (%d,%d)", localX, localY)
+	}
+
+	if target, _, _ := root.hitTest(0, 0); target != root {
+		t.Fatalf("expected hitTest to fall back to root outside the sub-console")
+	}
+}
+
+func TestDispatchRoutesToFocusedHandler(t *testing.T) {
+	root, err := NewHeadless(10, 10, newTestFont(t), "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := root.CreateSubConsole(0, 0, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &stubInteractive{}
+	sub.SetInteractive(h)
+	if err := root.PushFocus(sub); err != nil {
+		t.Fatal(err)
+	}
+
+	root.dispatch(Event{Type: EventKey, Key: ebiten.KeyEnter})
+	if len(h.events) != 1 {
+		t.Fatalf("expected the focused handler to receive the dispatched event, got %d", len(h.events))
+	}
+}
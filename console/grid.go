@@ -0,0 +1,193 @@
+package console
+
+import "fmt"
+
+// DimType describes how a Grid row or column dimension is sized.
+type DimType int
+
+const (
+	// Fixed reserves exactly Size cells.
+	Fixed DimType = iota
+	// Weighted shares the remaining space (after Fixed and Percent
+	// dimensions are subtracted) proportionally to Size among all other
+	// Weighted dimensions in the same axis.
+	Weighted
+	// Percent reserves Size percent (0-100) of the grid's total size on
+	// that axis.
+	Percent
+)
+
+// Dim describes the size of a single Grid row or column.
+type Dim struct {
+	Type DimType
+	Size int
+}
+
+// gridChild tracks a sub-console placed into a Grid along with the grid
+// cell range it occupies.
+type gridChild struct {
+	con              *Console
+	row, col         int
+	rowSpan, colSpan int
+}
+
+// Grid lays out a console's sub-consoles into rows and columns, modeled
+// after aerc's ui.Grid. Children are (re-)positioned and resized whenever
+// the grid itself is resized.
+type Grid struct {
+	parent *Console
+	rows   []Dim
+	cols   []Dim
+
+	children []*gridChild
+}
+
+// NewGrid creates a grid that lays out sub-consoles of parent according to
+// rows and cols. parent.Resize automatically calls Layout afterwards, so
+// resizing the parent keeps the grid's children in sync without the
+// caller having to remember to re-layout by hand.
+func NewGrid(parent *Console, rows, cols []Dim) *Grid {
+	g := &Grid{
+		parent: parent,
+		rows:   rows,
+		cols:   cols,
+	}
+	parent.grid = g
+	return g
+}
+
+// GridChild is the builder returned by AddChild, used to position a
+// sub-console within the grid.
+type GridChild struct {
+	grid  *Grid
+	child *gridChild
+}
+
+// AddChild registers con as a child of the grid. The returned GridChild is
+// used to set its position via At and, optionally, its span via Span.
+func (g *Grid) AddChild(con *Console) *GridChild {
+	c := &gridChild{con: con, rowSpan: 1, colSpan: 1}
+	g.children = append(g.children, c)
+	return &GridChild{grid: g, child: c}
+}
+
+// At places the child at the given row and column of the grid.
+func (gc *GridChild) At(row, col int) *GridChild {
+	gc.child.row = row
+	gc.child.col = col
+	return gc
+}
+
+// Span makes the child occupy rowspan rows and colspan columns, starting
+// at the position set by At.
+func (gc *GridChild) Span(rowspan, colspan int) *GridChild {
+	gc.child.rowSpan = rowspan
+	gc.child.colSpan = colspan
+	return gc
+}
+
+// resolveDims computes the cell offsets and sizes for each entry in dims given
+// the total available size along that axis.
+func resolveDims(dims []Dim, total int) ([]int, []int, error) {
+	offsets := make([]int, len(dims))
+	sizes := make([]int, len(dims))
+
+	remaining := total
+	weightTotal := 0
+	for _, d := range dims {
+		switch d.Type {
+		case Fixed:
+			remaining -= d.Size
+		case Percent:
+			remaining -= total * d.Size / 100
+		case Weighted:
+			weightTotal += d.Size
+		}
+	}
+
+	if remaining < 0 {
+		return nil, nil, fmt.Errorf("grid dimensions exceed available space")
+	}
+
+	// Weighted sizes are derived from the running share of weightTotal
+	// consumed so far rather than each dim's share in isolation, so the
+	// remainder from integer division accumulates onto later dims instead
+	// of being silently dropped (e.g. three equal Weighted{1} columns over
+	// width 100 become 33/33/34, not 33/33/33).
+	offset := 0
+	weightAssigned := 0
+	weightConsumed := 0
+	for i, d := range dims {
+		var size int
+		switch d.Type {
+		case Fixed:
+			size = d.Size
+		case Percent:
+			size = total * d.Size / 100
+		case Weighted:
+			if weightTotal > 0 {
+				weightConsumed += d.Size
+				cumulative := remaining * weightConsumed / weightTotal
+				size = cumulative - weightAssigned
+				weightAssigned = cumulative
+			}
+		}
+
+		offsets[i] = offset
+		sizes[i] = size
+		offset += size
+	}
+
+	return offsets, sizes, nil
+}
+
+// Layout recomputes every child sub-console's position and size based on
+// the parent console's current Width/Height. Call it after changing the
+// grid's rows/cols; parent.Resize already calls it after a resize.
+func (g *Grid) Layout() error {
+	colOffsets, colSizes, err := resolveDims(g.cols, g.parent.Width)
+	if err != nil {
+		return err
+	}
+
+	rowOffsets, rowSizes, err := resolveDims(g.rows, g.parent.Height)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range g.children {
+		if c.row < 0 || c.row+c.rowSpan > len(g.rows) || c.col < 0 || c.col+c.colSpan > len(g.cols) {
+			return fmt.Errorf("grid child out of bounds")
+		}
+
+		x := colOffsets[c.col]
+		y := rowOffsets[c.row]
+
+		width := 0
+		for i := c.col; i < c.col+c.colSpan; i++ {
+			width += colSizes[i]
+		}
+
+		height := 0
+		for i := c.row; i < c.row+c.rowSpan; i++ {
+			height += rowSizes[i]
+		}
+
+		c.con.mtx.Lock()
+		c.con.x = x
+		c.con.y = y
+		resized := c.con.Width != width || c.con.Height != height
+		c.con.mtx.Unlock()
+
+		// Resize discards buffer contents even when the size didn't
+		// change, so only call it for children whose size actually
+		// changed by this relayout.
+		if resized {
+			if err := c.con.Resize(width, height); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
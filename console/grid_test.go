@@ -0,0 +1,102 @@
+package console
+
+import "testing"
+
+func TestResolveDimsDistributesRoundingRemainder(t *testing.T) {
+	offsets, sizes, err := resolveDims([]Dim{
+		{Type: Weighted, Size: 1},
+		{Type: Weighted, Size: 1},
+		{Type: Weighted, Size: 1},
+	}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0
+	for _, s := range sizes {
+		total += s
+	}
+	if total != 100 {
+		t.Fatalf("expected weighted sizes to sum to the full width, got %d (%v)", total, sizes)
+	}
+	if offsets[0] != 0 || offsets[1] != sizes[0] || offsets[2] != sizes[0]+sizes[1] {
+		t.Fatalf("expected offsets to be the running sum of sizes, got %v (sizes %v)", offsets, sizes)
+	}
+}
+
+func TestResolveDimsMixedTypes(t *testing.T) {
+	_, sizes, err := resolveDims([]Dim{
+		{Type: Fixed, Size: 10},
+		{Type: Percent, Size: 20},
+		{Type: Weighted, Size: 1},
+		{Type: Weighted, Size: 3},
+	}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fixed=10, Percent=20 -> remaining=70, split 1:3 -> 17/53 (70*1/4=17, 70*4/4-17=53)
+	if sizes[0] != 10 || sizes[1] != 20 || sizes[2] != 17 || sizes[3] != 53 {
+		t.Fatalf("unexpected sizes: %v", sizes)
+	}
+}
+
+func TestResolveDimsOverflowErrors(t *testing.T) {
+	_, _, err := resolveDims([]Dim{{Type: Fixed, Size: 200}}, 100)
+	if err == nil {
+		t.Fatal("expected an error when fixed dims exceed the available total")
+	}
+}
+
+func TestGridLayoutPositionsChildren(t *testing.T) {
+	root, err := NewHeadless(100, 10, newTestFont(t), "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewGrid(root, []Dim{{Type: Weighted, Size: 1}}, []Dim{
+		{Type: Weighted, Size: 1},
+		{Type: Weighted, Size: 1},
+		{Type: Weighted, Size: 1},
+	})
+
+	sub, err := root.CreateSubConsole(0, 0, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.AddChild(sub).At(0, 1)
+
+	if err := g.Layout(); err != nil {
+		t.Fatal(err)
+	}
+
+	if sub.x != 33 || sub.Width != 33 {
+		t.Fatalf("expected the middle column to be positioned at x=33 with width 33, got x=%d width=%d", sub.x, sub.Width)
+	}
+}
+
+func TestConsoleResizeRelayoutsAttachedGrid(t *testing.T) {
+	root, err := NewHeadless(100, 10, newTestFont(t), "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewGrid(root, []Dim{{Type: Weighted, Size: 1}}, []Dim{
+		{Type: Weighted, Size: 1},
+		{Type: Weighted, Size: 1},
+	})
+
+	sub, err := root.CreateSubConsole(0, 0, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.AddChild(sub).At(0, 0)
+
+	if err := root.Resize(200, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if sub.Width != 100 {
+		t.Fatalf("expected resizing the parent to automatically relayout the grid, got child width %d", sub.Width)
+	}
+}
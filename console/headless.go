@@ -0,0 +1,90 @@
+package console
+
+import (
+	"image"
+	"image/draw"
+	"sync"
+
+	"github.com/BigJk/ramen"
+	"github.com/BigJk/ramen/font"
+)
+
+// NewHeadless creates a console that only maintains its [][]ramen.Cell
+// buffer, skipping the ebiten.Image line buffers New allocates. This lets
+// downstream games (and ramen itself) write tests for Print/Clear/
+// Transform/sub-console composition without an X display, using Snapshot
+// or RenderToImage to assert on the result.
+func NewHeadless(width, height int, font *font.Font, title string) (*Console, error) {
+	return &Console{
+		Title:       title,
+		Width:       width,
+		Height:      height,
+		Font:        font,
+		SubConsoles: make([]*Console, 0),
+		mtx:         new(sync.RWMutex),
+		dirty:       make(map[int]struct{}),
+		buffer:      newBuffer(width, height),
+		headless:    true,
+	}, nil
+}
+
+// Snapshot returns a copy of the console's current cell buffer, safe to
+// inspect without risk of a concurrent Transform/Clear mutating it.
+func (c *Console) Snapshot() [][]ramen.Cell {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	out := make([][]ramen.Cell, len(c.buffer))
+	for x := range c.buffer {
+		out[x] = make([]ramen.Cell, len(c.buffer[x]))
+		copy(out[x], c.buffer[x])
+	}
+	return out
+}
+
+// RenderToImage software-renders the console's current buffer into an
+// image.Image using Font.Image as the glyph atlas, the same way the
+// ebiten-backed draw path would, but without needing a graphics context.
+// Unlike that path, it draws a wide glyph only within its lead cell:
+// continuation cells get their own background and no glyph half.
+func (c *Console) RenderToImage() image.Image {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	img := image.NewRGBA(image.Rect(0, 0, c.Width*c.Font.TileWidth, c.Height*c.Font.TileHeight))
+
+	for x := range c.buffer {
+		for y := range c.buffer[x] {
+			cell := c.buffer[x][y]
+			dst := image.Rect(x*c.Font.TileWidth, y*c.Font.TileHeight, (x+1)*c.Font.TileWidth, (y+1)*c.Font.TileHeight)
+
+			if cell.Background.A > 0 {
+				draw.Draw(img, dst, image.NewUniform(cell.Background), image.Point{}, draw.Over)
+			}
+
+			if cell.Char == 0 {
+				continue
+			}
+
+			// ToOptions encodes the glyph's position within the atlas as a
+			// GeoM translation that, combined with the caller's own
+			// translate, lands only that tile inside the destination
+			// image when drawn with ebiten. Applying it to the origin
+			// recovers that same source rectangle for software rendering.
+			op := c.Font.ToOptions(cell.Char)
+			ax, ay := op.GeoM.Apply(0, 0)
+			src := image.Pt(int(-ax), int(-ay))
+
+			if c.Font.IsTile(cell.Char) {
+				draw.Draw(img, dst, c.Font.Image, src, draw.Over)
+			} else {
+				// Non-tile glyphs are tinted by foreground color in the
+				// ebiten path via ColorM.Scale; using the glyph itself as
+				// an alpha mask over a solid fg color reproduces that.
+				draw.DrawMask(img, dst, image.NewUniform(cell.Foreground), image.Point{}, c.Font.Image, src, draw.Over)
+			}
+		}
+	}
+
+	return img
+}
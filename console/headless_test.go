@@ -0,0 +1,63 @@
+package console
+
+import (
+	"testing"
+
+	ramenT "github.com/BigJk/ramen/t"
+)
+
+func TestHeadlessPrintAndClear(t *testing.T) {
+	con, err := NewHeadless(10, 4, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	con.Print(0, 0, "hi", ramenT.Foreground(defaultFg))
+
+	snap := con.Snapshot()
+	if snap[0][0].Char != 'h' || snap[1][0].Char != 'i' {
+		t.Fatalf("unexpected cells after Print: %q %q", snap[0][0].Char, snap[1][0].Char)
+	}
+
+	if err := con.Clear(0, 0, 10, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	snap = con.Snapshot()
+	if snap[0][0].Char != 0 {
+		t.Fatalf("expected cell to be cleared, got %q", snap[0][0].Char)
+	}
+}
+
+func TestHeadlessSubConsoleComposition(t *testing.T) {
+	root, err := NewHeadless(10, 10, newTestFont(t), "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := root.CreateSubConsole(2, 2, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub.Print(0, 0, "x")
+
+	snap := sub.Snapshot()
+	if snap[0][0].Char != 'x' {
+		t.Fatalf("expected sub-console cell to hold printed char, got %q", snap[0][0].Char)
+	}
+}
+
+func TestHeadlessRenderToImage(t *testing.T) {
+	con, err := NewHeadless(2, 2, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	con.Print(0, 0, "a")
+
+	img := con.RenderToImage()
+	bounds := img.Bounds()
+	if bounds.Dx() != 2*con.Font.TileWidth || bounds.Dy() != 2*con.Font.TileHeight {
+		t.Fatalf("unexpected rendered image size: %v", bounds)
+	}
+}
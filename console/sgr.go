@@ -0,0 +1,17 @@
+package console
+
+import "github.com/BigJk/ramen/t"
+
+var defaultFg = t.DefaultFg
+var defaultBg = t.DefaultBg
+
+// sgrState wraps the shared t.SGRState so vtParser can embed it anonymously
+// and PrintANSI can use it without duplicating the SGR state machine that
+// t.FromANSI also needs.
+type sgrState struct {
+	t.SGRState
+}
+
+func newSGRState() sgrState {
+	return sgrState{SGRState: t.NewSGRState()}
+}
@@ -0,0 +1,230 @@
+package console
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/creack/pty"
+	"github.com/hajimehoshi/ebiten"
+)
+
+// TerminalConsole wraps a Console and attaches it to a pseudo-terminal
+// running a child process. Output from the child is parsed as VT100/xterm
+// escape sequences and rendered into the wrapped console; keyboard input
+// is encoded back into the pty.
+type TerminalConsole struct {
+	*Console
+
+	cmd    *exec.Cmd
+	pty    *pty.File
+	parser *vtParser
+
+	out     chan []byte
+	focused bool
+}
+
+// NewTerminal creates a sub-console at x, y with the given size and spawns
+// cmd attached to a pseudo-terminal. The child's output is continuously
+// parsed and blitted into the sub-console.
+func NewTerminal(parent *Console, x, y, width, height int, cmd []string) (*TerminalConsole, error) {
+	if len(cmd) == 0 {
+		return nil, fmt.Errorf("no command given")
+	}
+
+	sub, err := parent.CreateSubConsole(x, y, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	c := exec.Command(cmd[0], cmd[1:]...)
+	f, err := pty.StartWithSize(c, &pty.Winsize{Rows: uint16(height), Cols: uint16(width)})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't start pty: %w", err)
+	}
+
+	tc := &TerminalConsole{
+		Console: sub,
+		cmd:     c,
+		pty:     f,
+		parser:  newVTParser(height),
+		out:     make(chan []byte, 64),
+	}
+
+	sub.SetInteractive(tc)
+
+	go tc.readLoop()
+
+	return tc, nil
+}
+
+// readLoop continuously reads the pty master and forwards chunks to the
+// Update goroutine so that parsing happens on the caller's timeline rather
+// than on the read goroutine.
+func (tc *TerminalConsole) readLoop() {
+	buf := make([]byte, 4096)
+	for {
+		n, err := tc.pty.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			tc.out <- chunk
+		}
+		if err != nil {
+			if err != io.EOF {
+				// Nothing meaningful to do with a dead pty besides stop reading.
+			}
+			close(tc.out)
+			_ = tc.cmd.Wait()
+			return
+		}
+	}
+}
+
+// Update drains any output produced by the child process since the last
+// call and applies it to the console. It should be called once per frame,
+// e.g. from the parent console's render hook.
+func (tc *TerminalConsole) Update() {
+	for {
+		select {
+		case chunk, ok := <-tc.out:
+			if !ok {
+				return
+			}
+			tc.parser.Feed(tc.Console, chunk)
+		default:
+			return
+		}
+	}
+}
+
+// Focus marks the terminal as the keyboard input target.
+func (tc *TerminalConsole) Focus(focused bool) {
+	tc.focused = focused
+}
+
+// HandleEvent implements Interactive by encoding key and character events
+// into the byte sequences the pty expects.
+func (tc *TerminalConsole) HandleEvent(e Event) bool {
+	switch e.Type {
+	case EventChar:
+		_, _ = tc.Write([]byte(string(e.Char)))
+		return true
+	case EventKey:
+		if err := tc.SendKey(e.Key, e.Mod); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Write sends raw bytes to the child process, as if typed at the terminal.
+func (tc *TerminalConsole) Write(p []byte) (int, error) {
+	return tc.pty.Write(p)
+}
+
+// SendKey encodes an ebiten key press, combined with any held modifiers,
+// into the byte sequence a real terminal would have produced and writes
+// it to the pty. Ctrl+<letter> is encoded as its control byte (e.g.
+// Ctrl+C -> 0x03) rather than an escape sequence, matching real terminal
+// behavior for signalling/EOF.
+func (tc *TerminalConsole) SendKey(key ebiten.Key, mod Modifier) error {
+	if mod&ModCtrl != 0 {
+		if b, ok := ctrlCode(key); ok {
+			_, err := tc.Write([]byte{b})
+			return err
+		}
+	}
+
+	seq, ok := keyEscapeSequences[key]
+	if !ok {
+		return fmt.Errorf("unhandled key %v", key)
+	}
+
+	if mod != 0 {
+		if modified, ok := modifiedEscapeSequence(key, mod); ok {
+			seq = modified
+		}
+	}
+
+	_, err := tc.Write(seq)
+	return err
+}
+
+// ctrlCode returns the control byte a terminal sends for Ctrl+key, e.g.
+// Ctrl+C -> 0x03, Ctrl+D -> 0x04. Only letter keys produce a control
+// byte.
+func ctrlCode(key ebiten.Key) (byte, bool) {
+	if key < ebiten.KeyA || key > ebiten.KeyZ {
+		return 0, false
+	}
+	return byte(key-ebiten.KeyA) + 1, true
+}
+
+// modifiedEscapeSequence re-encodes an arrow/navigation key's CSI
+// sequence with an xterm modifier parameter (e.g. Shift+Up -> ESC[1;2A)
+// in place of the bare form in keyEscapeSequences.
+func modifiedEscapeSequence(key ebiten.Key, mod Modifier) ([]byte, bool) {
+	final, ok := csiFinalBytes[key]
+	if !ok {
+		return nil, false
+	}
+
+	modParam := 1
+	if mod&ModShift != 0 {
+		modParam++
+	}
+	if mod&ModAlt != 0 {
+		modParam += 2
+	}
+	if mod&ModCtrl != 0 {
+		modParam += 4
+	}
+
+	return []byte(fmt.Sprintf("\x1b[1;%d%c", modParam, final)), true
+}
+
+var csiFinalBytes = map[ebiten.Key]byte{
+	ebiten.KeyUp:    'A',
+	ebiten.KeyDown:  'B',
+	ebiten.KeyRight: 'C',
+	ebiten.KeyLeft:  'D',
+	ebiten.KeyHome:  'H',
+	ebiten.KeyEnd:   'F',
+}
+
+var keyEscapeSequences = map[ebiten.Key][]byte{
+	ebiten.KeyUp:        []byte("\x1b[A"),
+	ebiten.KeyDown:      []byte("\x1b[B"),
+	ebiten.KeyRight:     []byte("\x1b[C"),
+	ebiten.KeyLeft:      []byte("\x1b[D"),
+	ebiten.KeyHome:      []byte("\x1b[H"),
+	ebiten.KeyEnd:       []byte("\x1b[F"),
+	ebiten.KeyBackspace: []byte{0x7f},
+	ebiten.KeyEnter:     []byte{'\r'},
+	ebiten.KeyTab:       []byte{'\t'},
+	ebiten.KeyEscape:    []byte{0x1b},
+}
+
+// Resize reallocates the underlying console buffer to the new size and
+// sends a SIGWINCH to the child process so it can reflow its own output.
+func (tc *TerminalConsole) Resize(width, height int) error {
+	if err := tc.Console.Resize(width, height); err != nil {
+		return err
+	}
+
+	// A shrink can leave a DECSTBM scroll region set by the child
+	// pointing past the new height (or with scrollTop > scrollBottom);
+	// reset to the full screen rather than risk scrollUp being called
+	// with an invalid range.
+	tc.parser.scrollTop = 0
+	tc.parser.scrollBottom = height - 1
+
+	return pty.Setsize(tc.pty, &pty.Winsize{Rows: uint16(height), Cols: uint16(width)})
+}
+
+// Close terminates the child process and closes the pty.
+func (tc *TerminalConsole) Close() error {
+	_ = tc.pty.Close()
+	return tc.cmd.Process.Kill()
+}
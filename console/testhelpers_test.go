@@ -0,0 +1,18 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/BigJk/ramen/font"
+	"github.com/hajimehoshi/ebiten"
+)
+
+// newTestFont returns a minimal font usable in tests/benchmarks that
+// never actually open a window.
+func newTestFont(tb testing.TB) *font.Font {
+	img, err := ebiten.NewImage(128, 128, ebiten.FilterNearest)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return &font.Font{TileWidth: 8, TileHeight: 16, Image: img}
+}
@@ -0,0 +1,229 @@
+package console
+
+import (
+	"strings"
+
+	"github.com/BigJk/ramen/t"
+)
+
+// vtParserState describes which part of an escape sequence the parser is
+// currently consuming.
+type vtParserState int
+
+const (
+	vtStateNormal vtParserState = iota
+	vtStateEscape
+	vtStateCSI
+)
+
+// vtParser is a small VT100 / xterm compatible escape-sequence parser. It
+// keeps track of the cursor position and current SGR attributes of a
+// terminal session and applies them onto a Console via Transform.
+type vtParser struct {
+	state vtParserState
+	csi   strings.Builder
+
+	cursorX, cursorY int
+	savedX, savedY   int
+
+	sgrState
+
+	autowrap  bool
+	altScreen bool
+
+	scrollTop, scrollBottom int
+}
+
+// newVTParser creates a parser reset to the default terminal state for a
+// console of the given size.
+func newVTParser(height int) *vtParser {
+	return &vtParser{
+		sgrState:     newSGRState(),
+		autowrap:     true,
+		scrollTop:    0,
+		scrollBottom: height - 1,
+	}
+}
+
+// Feed parses p and applies every contained cell change and cursor motion
+// onto con. It is safe to call Feed with partial escape sequences split
+// across reads; unterminated sequences are buffered until the next call.
+func (p *vtParser) Feed(con *Console, data []byte) {
+	for _, b := range data {
+		switch p.state {
+		case vtStateNormal:
+			p.feedNormal(con, b)
+		case vtStateEscape:
+			p.feedEscape(con, b)
+		case vtStateCSI:
+			p.feedCSI(con, b)
+		}
+	}
+}
+
+func (p *vtParser) feedNormal(con *Console, b byte) {
+	switch b {
+	case 0x1b:
+		p.state = vtStateEscape
+	case '\r':
+		p.cursorX = 0
+	case '\n':
+		p.newline(con)
+	case '\b':
+		if p.cursorX > 0 {
+			p.cursorX--
+		}
+	default:
+		p.putChar(con, rune(b))
+	}
+}
+
+func (p *vtParser) feedEscape(con *Console, b byte) {
+	switch b {
+	case '[':
+		p.csi.Reset()
+		p.state = vtStateCSI
+	default:
+		// Unsupported single-character escape, ignore and resume.
+		p.state = vtStateNormal
+	}
+}
+
+func (p *vtParser) feedCSI(con *Console, b byte) {
+	if b >= '0' && b <= '9' || b == ';' || b == '?' {
+		p.csi.WriteByte(b)
+		return
+	}
+
+	p.dispatchCSI(con, b, p.csi.String())
+	p.state = vtStateNormal
+}
+
+func (p *vtParser) param(params []int, i, def int) int {
+	if i >= len(params) || params[i] == 0 {
+		return def
+	}
+	return params[i]
+}
+
+func (p *vtParser) dispatchCSI(con *Console, final byte, raw string) {
+	if strings.HasPrefix(raw, "?") {
+		p.dispatchPrivate(con, final, raw[1:])
+		return
+	}
+
+	params := t.ParseCSIParams(raw)
+
+	switch final {
+	case 'H', 'f': // CUP
+		p.cursorY = p.param(params, 0, 1) - 1
+		p.cursorX = p.param(params, 1, 1) - 1
+	case 'A': // CUU
+		p.cursorY -= p.param(params, 0, 1)
+	case 'B': // CUD
+		p.cursorY += p.param(params, 0, 1)
+	case 'C': // CUF
+		p.cursorX += p.param(params, 0, 1)
+	case 'D': // CUB
+		p.cursorX -= p.param(params, 0, 1)
+	case 'J': // ED
+		p.eraseDisplay(con, p.param(params, 0, 0))
+	case 'K': // EL
+		p.eraseLine(con, p.param(params, 0, 0))
+	case 'r': // DECSTBM
+		p.scrollTop = p.param(params, 0, 1) - 1
+		p.scrollBottom = p.param(params, 1, con.Height) - 1
+	case 'm': // SGR
+		p.Apply(params)
+	}
+
+	p.clampCursor(con)
+}
+
+func (p *vtParser) dispatchPrivate(con *Console, final byte, raw string) {
+	params := t.ParseCSIParams(raw)
+	if final != 'h' && final != 'l' {
+		return
+	}
+	set := final == 'h'
+
+	switch p.param(params, 0, 0) {
+	case 7: // DECAWM
+		p.autowrap = set
+	case 1049: // alternate screen buffer
+		p.altScreen = set
+		con.ClearAll()
+		p.cursorX, p.cursorY = 0, 0
+	}
+}
+
+func (p *vtParser) eraseDisplay(con *Console, mode int) {
+	switch mode {
+	case 0:
+		// Erase from cursor to end of screen: the cursor's own row only
+		// from cursorX onward, then every row below in full.
+		p.eraseLine(con, 0)
+		if p.cursorY+1 < con.Height {
+			con.Clear(0, p.cursorY+1, con.Width, con.Height-p.cursorY-1)
+		}
+	case 1:
+		// Erase from start of screen to cursor: every row above in full,
+		// then the cursor's own row up to and including cursorX.
+		if p.cursorY > 0 {
+			con.Clear(0, 0, con.Width, p.cursorY)
+		}
+		p.eraseLine(con, 1)
+	case 2, 3:
+		con.ClearAll()
+	}
+}
+
+func (p *vtParser) eraseLine(con *Console, mode int) {
+	switch mode {
+	case 0:
+		con.Clear(p.cursorX, p.cursorY, con.Width-p.cursorX, 1)
+	case 1:
+		con.Clear(0, p.cursorY, p.cursorX+1, 1)
+	case 2:
+		con.Clear(0, p.cursorY, con.Width, 1)
+	}
+}
+
+func (p *vtParser) putChar(con *Console, r rune) {
+	if p.cursorX >= con.Width {
+		if !p.autowrap {
+			return
+		}
+		p.cursorX = 0
+		p.newline(con)
+	}
+
+	fg, bg := p.Resolve()
+
+	con.Transform(p.cursorX, p.cursorY, t.Char(r), t.Foreground(fg), t.Background(bg))
+	p.cursorX++
+}
+
+func (p *vtParser) newline(con *Console) {
+	if p.cursorY == p.scrollBottom {
+		con.scrollUp(p.scrollTop, p.scrollBottom)
+		return
+	}
+	p.cursorY++
+	p.clampCursor(con)
+}
+
+func (p *vtParser) clampCursor(con *Console) {
+	if p.cursorX < 0 {
+		p.cursorX = 0
+	}
+	if p.cursorX >= con.Width {
+		p.cursorX = con.Width - 1
+	}
+	if p.cursorY < 0 {
+		p.cursorY = 0
+	}
+	if p.cursorY >= con.Height {
+		p.cursorY = con.Height - 1
+	}
+}
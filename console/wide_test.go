@@ -0,0 +1,82 @@
+package console
+
+import (
+	"testing"
+
+	ramenT "github.com/BigJk/ramen/t"
+)
+
+func TestPrintWideCharacterReservesContinuation(t *testing.T) {
+	con, err := NewHeadless(4, 1, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	con.Print(0, 0, "中!") // CJK wide char followed by an ASCII char
+
+	snap := con.Snapshot()
+	if snap[0][0].Char != '中' || !snap[0][0].Wide {
+		t.Fatalf("expected cell 0 to hold the wide glyph, got %+v", snap[0][0])
+	}
+	if !snap[1][0].Continuation || snap[1][0].Char != 0 {
+		t.Fatalf("expected cell 1 to be an empty continuation cell, got %+v", snap[1][0])
+	}
+	if snap[2][0].Char != '!' {
+		t.Fatalf("expected '!' to land in cell 2 after the wide glyph, got %q", snap[2][0].Char)
+	}
+}
+
+func TestPrintWideCharacterAtRightEdgeIsNotOrphaned(t *testing.T) {
+	con, err := NewHeadless(1, 1, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	con.Print(0, 0, "中")
+
+	snap := con.Snapshot()
+	if snap[0][0].Wide {
+		t.Fatalf("expected Wide to be false when there's no room for a continuation cell, got %+v", snap[0][0])
+	}
+}
+
+func TestClearInvalidatesWidePair(t *testing.T) {
+	con, err := NewHeadless(2, 1, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	con.Print(0, 0, "中")
+	if err := con.Clear(0, 0, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	snap := con.Snapshot()
+	if snap[1][0].Continuation {
+		t.Fatalf("expected clearing the wide cell to invalidate its continuation partner, got %+v", snap[1][0])
+	}
+}
+
+func TestTransformOverwritingLeadHalfClearsItsOwnWideFlag(t *testing.T) {
+	con, err := NewHeadless(2, 1, newTestFont(t), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	con.Print(0, 0, "中")
+
+	// Overwrite the lead cell directly via Transform, bypassing Print's
+	// own setWide bookkeeping, the way a caller using Transform/Snapshot
+	// directly would.
+	if err := con.Transform(0, 0, ramenT.Char('a')); err != nil {
+		t.Fatal(err)
+	}
+
+	snap := con.Snapshot()
+	if snap[0][0].Wide {
+		t.Fatalf("expected overwriting the lead cell to clear its own stale Wide flag, got %+v", snap[0][0])
+	}
+	if snap[1][0].Continuation {
+		t.Fatalf("expected the continuation partner to be invalidated too, got %+v", snap[1][0])
+	}
+}
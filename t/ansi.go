@@ -0,0 +1,33 @@
+package t
+
+// FromANSI scans s for SGR escape sequences (\x1b[31m, 256-color
+// \x1b[38;5;208m, truecolor \x1b[38;2;R;G;Bm, reset, bold, reverse) and
+// returns the Transformers needed to reproduce the style reached at the
+// end of the string. It does not touch the character content of s and
+// does not strip the escape sequences from it, so Console.Print(x, y, s,
+// t.FromANSI(s)...) would print those sequences as glyphs; pass Print the
+// text without them, or use Console.PrintANSI, which does both in one step.
+func FromANSI(s string) []Transformer {
+	state := NewSGRState()
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != 0x1b || i+1 >= len(runes) || runes[i+1] != '[' {
+			continue
+		}
+
+		end := i + 2
+		for end < len(runes) && runes[end] != 'm' {
+			end++
+		}
+		if end >= len(runes) {
+			break
+		}
+
+		state.Apply(ParseCSIParams(string(runes[i+2 : end])))
+		i = end
+	}
+
+	fg, bg := state.Resolve()
+	return []Transformer{Foreground(fg), Background(bg)}
+}
@@ -0,0 +1,135 @@
+package t
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/BigJk/ramen/consolecolor"
+)
+
+// DefaultFg and DefaultBg are the colors a terminal/ANSI stream starts
+// with before any SGR sequence has been seen, and the colors codes 39/49
+// ("default foreground"/"default background") reset to.
+var DefaultFg = consolecolor.New(255, 255, 255)
+var DefaultBg = consolecolor.New(0, 0, 0)
+
+// SGRState holds the SGR (Select Graphic Rendition) attributes
+// accumulated while scanning ANSI-colored text. It's shared by
+// Console.PrintANSI, the VT100 terminal emulator, and FromANSI, which all
+// need to turn a stream of "\x1b[...m" sequences into a current
+// foreground/background/attribute state.
+type SGRState struct {
+	fg, bg consolecolor.Color
+	bold   bool
+	invert bool
+}
+
+// NewSGRState returns a state reset to the terminal defaults.
+func NewSGRState() SGRState {
+	return SGRState{fg: DefaultFg, bg: DefaultBg}
+}
+
+// Apply parses the already-split parameters of one or more SGR sequences
+// and updates the state accordingly.
+func (s *SGRState) Apply(params []int) {
+	if len(params) == 0 {
+		params = []int{0}
+	}
+
+	for i := 0; i < len(params); i++ {
+		switch code := params[i]; {
+		case code == 0:
+			*s = NewSGRState()
+		case code == 1:
+			s.bold = true
+		case code == 7:
+			s.invert = true
+		case code == 27:
+			s.invert = false
+		case code >= 30 && code <= 37:
+			s.fg = AnsiColor(code - 30)
+		case code == 38 && i+1 < len(params):
+			c, consumed := ExtendedColor(params[i+1:])
+			s.fg = c
+			i += consumed
+		case code == 39:
+			s.fg = DefaultFg
+		case code >= 40 && code <= 47:
+			s.bg = AnsiColor(code - 40)
+		case code == 48 && i+1 < len(params):
+			c, consumed := ExtendedColor(params[i+1:])
+			s.bg = c
+			i += consumed
+		case code == 49:
+			s.bg = DefaultBg
+		}
+	}
+}
+
+// Resolve returns the effective foreground/background to draw a cell
+// with, after accounting for reverse video and bold-as-bright.
+func (s SGRState) Resolve() (fg, bg consolecolor.Color) {
+	fg, bg = s.fg, s.bg
+	if s.invert {
+		fg, bg = bg, fg
+	}
+	if s.bold {
+		fg = fg.Brighten()
+	}
+	return fg, bg
+}
+
+// ParseCSIParams splits the numeric parameters of a CSI sequence (the
+// part between "\x1b[" and the final byte) on ';' into integers, treating
+// empty fields as 0 per the ANSI default-parameter convention.
+func ParseCSIParams(raw string) []int {
+	if raw == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ";")
+	out := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			n = 0
+		}
+		out[i] = n
+	}
+	return out
+}
+
+// ExtendedColor parses the remainder of a 256-color (38;5;N) or truecolor
+// (38;2;R;G;B) SGR sequence and returns the resolved color plus the
+// number of extra parameters consumed.
+func ExtendedColor(rest []int) (consolecolor.Color, int) {
+	if len(rest) == 0 {
+		return DefaultFg, 0
+	}
+
+	switch rest[0] {
+	case 5:
+		if len(rest) >= 2 {
+			return consolecolor.From256(rest[1]), 2
+		}
+	case 2:
+		if len(rest) >= 4 {
+			return consolecolor.New(uint8(rest[1]), uint8(rest[2]), uint8(rest[3])), 4
+		}
+	}
+	return DefaultFg, len(rest)
+}
+
+// AnsiColor maps a base SGR color index (0-7) to its ANSI palette color.
+func AnsiColor(i int) consolecolor.Color {
+	palette := [8]consolecolor.Color{
+		consolecolor.New(0, 0, 0),
+		consolecolor.New(205, 0, 0),
+		consolecolor.New(0, 205, 0),
+		consolecolor.New(205, 205, 0),
+		consolecolor.New(0, 0, 238),
+		consolecolor.New(205, 0, 205),
+		consolecolor.New(0, 205, 205),
+		consolecolor.New(229, 229, 229),
+	}
+	return palette[i%8]
+}
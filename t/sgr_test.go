@@ -0,0 +1,81 @@
+package t
+
+import (
+	"testing"
+
+	"github.com/BigJk/ramen"
+	"github.com/BigJk/ramen/consolecolor"
+)
+
+func TestSGRStateBasicColor(t *testing.T) {
+	s := NewSGRState()
+	s.Apply(ParseCSIParams("31")) // red foreground
+
+	fg, bg := s.Resolve()
+	if fg != consolecolor.New(205, 0, 0) {
+		t.Fatalf("expected ANSI red foreground, got %+v", fg)
+	}
+	if bg != DefaultBg {
+		t.Fatalf("expected background untouched, got %+v", bg)
+	}
+}
+
+func TestSGRState256Color(t *testing.T) {
+	s := NewSGRState()
+	s.Apply(ParseCSIParams("38;5;208"))
+
+	fg, _ := s.Resolve()
+	if fg != consolecolor.From256(208) {
+		t.Fatalf("expected 256-color 208 foreground, got %+v", fg)
+	}
+}
+
+func TestSGRStateTruecolor(t *testing.T) {
+	s := NewSGRState()
+	s.Apply(ParseCSIParams("38;2;10;20;30"))
+
+	fg, _ := s.Resolve()
+	if fg != consolecolor.New(10, 20, 30) {
+		t.Fatalf("expected truecolor foreground, got %+v", fg)
+	}
+}
+
+func TestSGRStateResetClearsAttributes(t *testing.T) {
+	s := NewSGRState()
+	s.Apply(ParseCSIParams("31;1;7")) // red, bold, reverse
+	s.Apply(ParseCSIParams("0"))      // reset
+
+	fg, bg := s.Resolve()
+	if fg != DefaultFg || bg != DefaultBg {
+		t.Fatalf("expected reset to restore defaults, got fg=%+v bg=%+v", fg, bg)
+	}
+}
+
+func TestSGRStateReverseSwapsForegroundAndBackground(t *testing.T) {
+	s := NewSGRState()
+	s.Apply(ParseCSIParams("31;7")) // red foreground, reverse video
+
+	fg, bg := s.Resolve()
+	if fg != DefaultBg || bg != consolecolor.New(205, 0, 0) {
+		t.Fatalf("expected fg/bg swapped by reverse video, got fg=%+v bg=%+v", fg, bg)
+	}
+}
+
+func TestFromANSIUnterminatedEscapeIsIgnored(t *testing.T) {
+	// A sequence truncated mid-escape should stop parsing rather than
+	// mis-resolve a bogus color.
+	transformers := FromANSI("\x1b[31mred\x1b[1")
+	if len(transformers) != 2 {
+		t.Fatalf("expected 2 transformers (fg, bg), got %d", len(transformers))
+	}
+
+	var cell ramen.Cell
+	for _, tr := range transformers {
+		if _, err := tr.Transform(&cell); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cell.Foreground != consolecolor.New(205, 0, 0) || cell.Background != DefaultBg {
+		t.Fatalf("expected the truncated \\x1b[1 to be ignored, leaving plain red on default bg, got fg=%+v bg=%+v", cell.Foreground, cell.Background)
+	}
+}